@@ -0,0 +1,47 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"io"
+	"mime/multipart"
+	"os"
+)
+
+// MultipartForm parses and returns the multipart form, if any, read from the
+// request body, honoring Engine.MaxMultipartMemory.
+func (c *Context) MultipartForm() (*multipart.Form, error) {
+	err := c.Request.ParseMultipartForm(c.Engine.MaxMultipartMemory)
+	return c.Request.MultipartForm, err
+}
+
+// FormFile returns the first file for the given multipart form key.
+func (c *Context) FormFile(name string) (*multipart.FileHeader, error) {
+	if c.Request.MultipartForm == nil {
+		if err := c.Request.ParseMultipartForm(c.Engine.MaxMultipartMemory); err != nil {
+			return nil, err
+		}
+	}
+	_, fh, err := c.Request.FormFile(name)
+	return fh, err
+}
+
+// SaveUploadedFile uploads the form file to specific dst.
+func (c *Context) SaveUploadedFile(file *multipart.FileHeader, dst string) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, src)
+	return err
+}
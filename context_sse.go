@@ -0,0 +1,14 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import "fmt"
+
+// SSEventWithID writes a Server-Sent Event with an explicit id, so clients
+// that reconnect can send it back as Last-Event-ID and resume from there.
+func (c *Context) SSEventWithID(name string, id uint64, data interface{}) {
+	fmt.Fprintf(c.Writer, "id: %d\n", id)
+	c.SSEvent(name, data)
+}
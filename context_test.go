@@ -1,10 +1,16 @@
 package gin
 
 import (
+	"bytes"
 	"errors"
 	"html/template"
+	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 )
 
@@ -317,3 +323,101 @@ func TestFailHandlersChain(t *testing.T) {
 	}
 
 }
+
+// TestContextFormFile tests that a multipart/form-data upload can be read
+// back via FormFile and saved verbatim with SaveUploadedFile.
+func TestContextFormFile(t *testing.T) {
+	buf := new(bytes.Buffer)
+	mw := multipart.NewWriter(buf)
+	w, err := mw.CreateFormFile("file", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("test"))
+	mw.Close()
+
+	req, _ := http.NewRequest("POST", "/", buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	resp := httptest.NewRecorder()
+
+	r := Default()
+	r.POST("/", func(c *Context) {
+		file, err := c.FormFile("file")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dst := filepath.Join(os.TempDir(), "test")
+		if err := c.SaveUploadedFile(file, dst); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(dst)
+
+		data, err := ioutil.ReadFile(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(data) != "test" {
+			t.Error("SaveUploadedFile did not write the uploaded bytes verbatim")
+		}
+	})
+	r.ServeHTTP(resp, req)
+}
+
+// TestContextMultipartForm tests that MultipartForm parses posted form values.
+func TestContextMultipartForm(t *testing.T) {
+	buf := new(bytes.Buffer)
+	mw := multipart.NewWriter(buf)
+	mw.WriteField("foo", "bar")
+	mw.Close()
+
+	req, _ := http.NewRequest("POST", "/", buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	resp := httptest.NewRecorder()
+
+	r := Default()
+	r.POST("/", func(c *Context) {
+		form, err := c.MultipartForm()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if form.Value["foo"][0] != "bar" {
+			t.Errorf("form value foo should be bar, was %s", form.Value["foo"][0])
+		}
+	})
+	r.ServeHTTP(resp, req)
+}
+
+// TestContextHandleContextConcurrent fires hundreds of goroutines through a
+// handler that rewrites the request path and re-enters routing via
+// HandleContext, asserting there are no races and every request lands on
+// the correct final handler.
+func TestContextHandleContextConcurrent(t *testing.T) {
+	r := Default()
+	r.GET("/foo", func(c *Context) {
+		c.Request.URL.Path = "/bar"
+		r.HandleContext(c)
+	})
+	r.GET("/bar", func(c *Context) {
+		c.String(200, "bar")
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", "/foo", nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != 200 || w.Body.String() != "bar" {
+				t.Errorf("expected 200/bar, got %d/%s", w.Code, w.Body.String())
+			}
+		}()
+	}
+	wg.Wait()
+}
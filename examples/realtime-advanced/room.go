@@ -0,0 +1,125 @@
+package main
+
+import "sync"
+
+// backlogSize is how many past messages each room keeps around so that a
+// reconnecting client can replay what it missed via Last-Event-ID.
+const backlogSize = 256
+
+// sseMessage pairs a broadcast payload with the monotonically increasing id
+// assigned to it by its room, so streamRoom can replay it with the correct
+// "id:" line and reconnecting clients can ask to resume after it.
+type sseMessage struct {
+	ID      uint64
+	Payload interface{}
+}
+
+// broker fans out messages submitted to a single room to every connected
+// listener, and keeps a bounded ring buffer of recent messages for replay.
+type broker struct {
+	mu        sync.Mutex
+	nextID    uint64
+	backlog   []sseMessage
+	listeners map[chan sseMessage]bool
+}
+
+func newBroker() *broker {
+	return &broker{listeners: make(map[chan sseMessage]bool)}
+}
+
+// Submit assigns the next id to payload, appends it to the ring buffer and
+// broadcasts it to every currently connected listener. Sends are
+// non-blocking: a listener whose channel is full (its HTTP response is
+// stalled, e.g. behind a frozen proxy) is skipped rather than allowed to
+// stall Submit and, with it, every other subscriber and publisher of the
+// room.
+func (b *broker) Submit(payload interface{}) {
+	b.mu.Lock()
+	b.nextID++
+	msg := sseMessage{ID: b.nextID, Payload: payload}
+	b.backlog = append(b.backlog, msg)
+	if len(b.backlog) > backlogSize {
+		b.backlog = b.backlog[len(b.backlog)-backlogSize:]
+	}
+	listeners := make([]chan sseMessage, 0, len(b.listeners))
+	for listener := range b.listeners {
+		listeners = append(listeners, listener)
+	}
+	b.mu.Unlock()
+
+	for _, listener := range listeners {
+		select {
+		case listener <- msg:
+		default:
+		}
+	}
+}
+
+// subscribe registers a new listener and returns it along with any
+// backlogged messages after lastID. ok is false when some message after
+// lastID has already fallen off the ring buffer, or when the backlog is empty,
+// in which case the caller should ask the client to resync instead of
+// trusting backlog.
+func (b *broker) subscribe(lastID uint64) (listener chan sseMessage, backlog []sseMessage, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	listener = make(chan sseMessage, 8)
+	b.listeners[listener] = true
+
+	if lastID == 0 {
+		return listener, nil, true
+	}
+	if len(b.backlog) == 0 {
+		// No history at all (fresh broker, e.g. after a process restart)
+		// but the client claims to have seen lastID: we can't tell what,
+		// if anything, it missed, so treat it as lost rather than caught up.
+		return listener, nil, false
+	}
+	if lastID < b.backlog[0].ID-1 {
+		return listener, nil, false
+	}
+	for _, msg := range b.backlog {
+		if msg.ID > lastID {
+			backlog = append(backlog, msg)
+		}
+	}
+	return listener, backlog, true
+}
+
+func (b *broker) unsubscribe(listener chan sseMessage) {
+	b.mu.Lock()
+	delete(b.listeners, listener)
+	b.mu.Unlock()
+}
+
+var (
+	roomsMu sync.Mutex
+	rooms   = make(map[string]*broker)
+)
+
+// room returns the broker for roomid, creating it on first use.
+func room(roomid string) *broker {
+	roomsMu.Lock()
+	defer roomsMu.Unlock()
+
+	b, found := rooms[roomid]
+	if !found {
+		b = newBroker()
+		rooms[roomid] = b
+	}
+	return b
+}
+
+// openListener subscribes to roomid, returning a live channel plus any
+// backlogged messages after lastID (0 meaning "no replay requested"). ok is
+// false when some message after lastID has already fallen off the room's ring
+// buffer, or when the room has no backlog at all, in which case the caller
+// should ask the client to resync instead of trusting backlog.
+func openListener(roomid string, lastID uint64) (listener chan sseMessage, backlog []sseMessage, ok bool) {
+	return room(roomid).subscribe(lastID)
+}
+
+func closeListener(roomid string, listener chan sseMessage) {
+	room(roomid).unsubscribe(listener)
+}
@@ -0,0 +1,64 @@
+package main
+
+import "testing"
+
+// TestBrokerSubscribe covers subscribe's three outcomes: no replay
+// requested, replay served from the in-buffer backlog, and a resync when
+// the requested id has fallen off (or was never in) the ring buffer.
+func TestBrokerSubscribe(t *testing.T) {
+	tests := []struct {
+		name        string
+		submits     int    // number of messages submitted before subscribing
+		lastID      uint64 // Last-Event-ID presented by the reconnecting client
+		wantOK      bool
+		wantBacklog int // expected number of replayed messages
+	}{
+		{
+			name:        "no replay requested",
+			submits:     3,
+			lastID:      0,
+			wantOK:      true,
+			wantBacklog: 0,
+		},
+		{
+			name:        "in-buffer replay",
+			submits:     3,
+			lastID:      1,
+			wantOK:      true,
+			wantBacklog: 2,
+		},
+		{
+			name:        "fallen off an empty buffer",
+			submits:     0,
+			lastID:      1,
+			wantOK:      false,
+			wantBacklog: 0,
+		},
+		{
+			name:        "fallen off a trimmed buffer",
+			submits:     backlogSize + 10,
+			lastID:      1,
+			wantOK:      false,
+			wantBacklog: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBroker()
+			for i := 0; i < tt.submits; i++ {
+				b.Submit(i)
+			}
+
+			listener, backlog, ok := b.subscribe(tt.lastID)
+			defer b.unsubscribe(listener)
+
+			if ok != tt.wantOK {
+				t.Errorf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if len(backlog) != tt.wantBacklog {
+				t.Errorf("len(backlog) = %d, want %d", len(backlog), tt.wantBacklog)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"html"
 	"io"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 
@@ -70,7 +71,13 @@ func roomPOST(c *gin.Context) {
 
 func streamRoom(c *gin.Context) {
 	roomid := c.ParamValue("roomid")
-	listener := openListener(roomid)
+
+	var lastID uint64
+	if header := c.Request.Header.Get("Last-Event-ID"); header != "" {
+		lastID, _ = strconv.ParseUint(header, 10, 64)
+	}
+
+	listener, backlog, ok := openListener(roomid, lastID)
 	ticker := time.NewTicker(1 * time.Second)
 	users.Add("connected", 1)
 	defer func() {
@@ -79,11 +86,32 @@ func streamRoom(c *gin.Context) {
 		users.Add("disconnected", 1)
 	}()
 
+	// Set the SSE headers ourselves before the first byte goes out: the
+	// first Write() implicitly commits the status line and headers, and
+	// that happens below (the "retry:" line) before SSEvent ever gets a
+	// chance to set Content-Type in its render path.
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+
+	// Ask the browser to back off consistently on every reconnect, then
+	// either replay what it missed or tell it to resync if its last seen
+	// id has already fallen off our ring buffer.
+	io.WriteString(c.Writer, "retry: 3000\n")
+	if lastID > 0 && !ok {
+		c.SSEvent("resync", nil)
+	}
+	for _, msg := range backlog {
+		messages.Add("outbound", 1)
+		c.SSEventWithID("message", msg.ID, msg.Payload)
+	}
+
 	c.Stream(func(w io.Writer) bool {
 		select {
 		case msg := <-listener:
 			messages.Add("outbound", 1)
-			c.SSEvent("message", msg)
+			c.SSEventWithID("message", msg.ID, msg.Payload)
 		case <-ticker.C:
 			c.SSEvent("stats", Stats())
 		}
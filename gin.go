@@ -6,7 +6,9 @@ package gin
 
 import (
 	"html/template"
+	"net"
 	"net/http"
+	"os"
 	"sync"
 
 	"github.com/gin-gonic/gin/binding"
@@ -38,6 +40,25 @@ func (ps Params) ByName(name string) string {
 var default404Body = []byte("404 page not found")
 var default405Body = []byte("405 method not allowed")
 
+type methodTree struct {
+	method string
+	root   *node
+}
+
+type methodTrees []methodTree
+
+// get returns the tree root registered for the given HTTP method, or nil if
+// none has been registered. There are only a handful of HTTP methods in
+// practice, so a linear scan beats the hashing and allocation of a map.
+func (trees methodTrees) get(method string) *node {
+	for _, tree := range trees {
+		if tree.method == method {
+			return tree.root
+		}
+	}
+	return nil
+}
+
 type (
 	HandlerFunc func(*Context)
 
@@ -50,7 +71,7 @@ type (
 		allNoMethod []HandlerFunc
 		noRoute     []HandlerFunc
 		noMethod    []HandlerFunc
-		trees       map[string]*node
+		trees       methodTrees
 
 		// Enables automatic redirection if the current route can't be matched but a
 		// handler for the path with (without) the trailing slash exists.
@@ -77,6 +98,14 @@ type (
 		// If no other Method is allowed, the request is delegated to the NotFound
 		// handler.
 		HandleMethodNotAllowed bool
+
+		// MaxMultipartMemory is the maximum number of bytes used to parse a
+		// multipart form before falling back to writing to disk, passed
+		// through to ParseMultipartForm by Context.MultipartForm and friends.
+		MaxMultipartMemory int64
+
+		delims  render.Delims
+		funcMap template.FuncMap
 	}
 )
 
@@ -91,7 +120,10 @@ func New() *Engine {
 		RedirectTrailingSlash:  true,
 		RedirectFixedPath:      true,
 		HandleMethodNotAllowed: true,
-		trees: make(map[string]*node),
+		MaxMultipartMemory:     32 << 20, // 32 MB
+		delims:                 render.Delims{Left: "{{", Right: "}}"},
+		funcMap:                template.FuncMap{},
+		trees:                  make(methodTrees, 0, 9),
 	}
 	engine.RouterGroup.engine = engine
 	engine.pool.New = func() interface{} {
@@ -113,22 +145,43 @@ func (engine *Engine) allocateContext() (context *Context) {
 	return
 }
 
+// Delims sets the left and right delimiters used when parsing HTML templates,
+// e.g. for front-end frameworks whose own template syntax collides with the
+// default "{{" / "}}". It re-applies on every debug-mode reload.
+func (engine *Engine) Delims(left, right string) *Engine {
+	engine.delims = render.Delims{Left: left, Right: right}
+	return engine
+}
+
+// SetFuncMap sets the FuncMap used when parsing HTML templates.
+func (engine *Engine) SetFuncMap(funcMap template.FuncMap) {
+	engine.funcMap = funcMap
+}
+
 func (engine *Engine) LoadHTMLGlob(pattern string) {
 	if IsDebugging() {
-		r := &render.HTMLDebugRender{Glob: pattern}
+		r := &render.HTMLDebugRender{
+			Glob:    pattern,
+			Delims:  engine.delims,
+			FuncMap: engine.funcMap,
+		}
 		engine.HTMLRender = r
 	} else {
-		templ := template.Must(template.ParseGlob(pattern))
+		templ := template.Must(template.New("").Delims(engine.delims.Left, engine.delims.Right).Funcs(engine.funcMap).ParseGlob(pattern))
 		engine.SetHTMLTemplate(templ)
 	}
 }
 
 func (engine *Engine) LoadHTMLFiles(files ...string) {
 	if IsDebugging() {
-		r := &render.HTMLDebugRender{Files: files}
+		r := &render.HTMLDebugRender{
+			Files:   files,
+			Delims:  engine.delims,
+			FuncMap: engine.funcMap,
+		}
 		engine.HTMLRender = r
 	} else {
-		templ := template.Must(template.ParseFiles(files...))
+		templ := template.Must(template.New("").Delims(engine.delims.Left, engine.delims.Right).Funcs(engine.funcMap).ParseFiles(files...))
 		engine.SetHTMLTemplate(templ)
 	}
 }
@@ -168,10 +221,10 @@ func (engine *Engine) handle(method, path string, handlers []HandlerFunc) {
 	if path[0] != '/' {
 		panic("path must begin with '/'")
 	}
-	root := engine.trees[method]
+	root := engine.trees.get(method)
 	if root == nil {
 		root = new(node)
-		engine.trees[method] = root
+		engine.trees = append(engine.trees, methodTree{method: method, root: root})
 	}
 	root.addRoute(path, handlers)
 }
@@ -186,6 +239,35 @@ func (engine *Engine) RunTLS(addr string, cert string, key string) error {
 	return http.ListenAndServeTLS(addr, cert, key, engine)
 }
 
+// RunUnix attaches the router to a http.Server and starts listening and serving HTTP
+// requests through the given unix socket. It creates the socket file if it
+// doesn't already exist, and removes any stale socket file left behind by a
+// previous run before binding.
+func (engine *Engine) RunUnix(file string) error {
+	debugPrint("Listening and serving HTTP on unix:/%s\n", file)
+
+	os.Remove(file)
+	listener, err := net.Listen("unix", file)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	if err := os.Chmod(file, 0660); err != nil {
+		return err
+	}
+
+	return http.Serve(listener, engine)
+}
+
+// RunListener attaches the router to a http.Server and starts listening and
+// serving HTTP requests through the given net.Listener, e.g. for socket
+// activation, custom TLS configs or PROXY-protocol wrappers.
+func (engine *Engine) RunListener(l net.Listener) error {
+	debugPrint("Listening and serving HTTP on listener %s\n", l.Addr())
+	return http.Serve(l, engine)
+}
+
 // ServeHTTP makes the router implement the http.Handler interface.
 func (engine *Engine) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	context := engine.pool.Get().(*Context)
@@ -198,12 +280,23 @@ func (engine *Engine) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	engine.pool.Put(context)
 }
 
+// HandleContext re-enters the routing state machine for c.Request without
+// recycling c to the sync.Pool, so middleware can rewrite c.Request.URL.Path
+// and forward to whatever handler now matches. c.Keys and the response
+// writer are left untouched; only the routing-specific fields are reset.
+func (engine *Engine) HandleContext(c *Context) {
+	c.handlers = nil
+	c.index = -1
+	c.Params = c.Params[0:0]
+	engine.serveHTTPRequest(c)
+}
+
 func (engine *Engine) serveHTTPRequest(context *Context) {
 	httpMethod := context.Request.Method
 	path := context.Request.URL.Path
 
 	// Find root of the tree for the given HTTP method
-	if root := engine.trees[httpMethod]; root != nil {
+	if root := engine.trees.get(httpMethod); root != nil {
 		// Find route in tree
 		handlers, params, tsr := root.getValue(path, context.Params)
 		// Dispatch if we found any handlers
@@ -222,9 +315,9 @@ func (engine *Engine) serveHTTPRequest(context *Context) {
 	}
 
 	if engine.HandleMethodNotAllowed {
-		for method, root := range engine.trees {
-			if method != httpMethod {
-				if handlers, _, _ := root.getValue(path, nil); handlers != nil {
+		for _, tree := range engine.trees {
+			if tree.method != httpMethod {
+				if handlers, _, _ := tree.root.getValue(path, nil); handlers != nil {
 					context.handlers = engine.allNoMethod
 					serveError(context, 405, default405Body)
 					return
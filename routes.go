@@ -0,0 +1,61 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+)
+
+// RouteInfo represents a request route's specification which contains method and path and its handler.
+type RouteInfo struct {
+	Method      string
+	Path        string
+	Handler     string
+	HandlerFunc HandlerFunc
+}
+
+// RoutesInfo defines a RouteInfo slice.
+type RoutesInfo []RouteInfo
+
+// Routes returns a slice of registered routes, including some useful information such as:
+// the http method, path and the handler name.
+func (engine *Engine) Routes() (routes RoutesInfo) {
+	for _, tree := range engine.trees {
+		routes = engine.collectRoutes(routes, tree.root, "", tree.method)
+	}
+	return routes
+}
+
+func (engine *Engine) collectRoutes(routes RoutesInfo, n *node, path, method string) RoutesInfo {
+	path += n.path
+	if len(n.handlers) > 0 {
+		handlerFunc := n.handlers[len(n.handlers)-1]
+		routes = append(routes, RouteInfo{
+			Method:      method,
+			Path:        path,
+			Handler:     nameOfFunction(handlerFunc),
+			HandlerFunc: handlerFunc,
+		})
+	}
+	for _, child := range n.children {
+		routes = engine.collectRoutes(routes, child, path, method)
+	}
+	return routes
+}
+
+func nameOfFunction(f HandlerFunc) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
+
+// RoutesTable prints every registered route to w, one per line, for boot-time
+// verification of what RouterGroup actually wired up.
+func (engine *Engine) RoutesTable(w io.Writer) {
+	for _, route := range engine.Routes() {
+		fmt.Fprintf(w, "%-6s %-25s --> %s\n", route.Method, route.Path, route.Handler)
+	}
+}